@@ -0,0 +1,229 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DirectedCyclesIn returns all elementary circuits (simple cycles) of the
+// directed graph g, computed with Johnson's algorithm:
+//
+//	Donald B. Johnson, "Finding All the Elementary Circuits of a Directed
+//	Graph", SIAM J. Comput., 4(1) (1975), pp. 77-84.
+//
+// The graph is repeatedly decomposed into strongly connected components;
+// circuits are searched for via a stack-based DFS rooted at the
+// least-indexed remaining vertex of each component, using a blocked set to
+// avoid repeating fruitless search paths, before that vertex is discarded
+// and the next component is considered.
+//
+// Each circuit is returned as a closed walk: the first and last nodes are
+// equal, and consecutive nodes are connected by an edge in g.
+func DirectedCyclesIn(g graph.Directed) [][]graph.Node {
+	jg := newJohnsonGraph(g)
+	var circuits [][]graph.Node
+	jg.run(func(ids []int64) {
+		nodes := make([]graph.Node, len(ids))
+		for i, id := range ids {
+			nodes[i] = g.Node(id)
+		}
+		circuits = append(circuits, nodes)
+	})
+	return circuits
+}
+
+// johnsonGraph holds the working state of Johnson's algorithm: the set of
+// vertices already used as a search root (removed), the current DFS
+// stack, and the blocked/B bookkeeping used to prune and later reopen
+// search paths that did not lead anywhere.
+type johnsonGraph struct {
+	g     graph.Directed
+	nodes []int64 // all vertex IDs, ascending
+
+	removed map[int64]bool
+
+	blocked map[int64]bool
+	bSet    map[int64]map[int64]bool
+	stack   []int64
+}
+
+func newJohnsonGraph(g graph.Directed) *johnsonGraph {
+	all := graph.NodesOf(g.Nodes())
+	ids := make([]int64, len(all))
+	for i, n := range all {
+		ids[i] = n.ID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return &johnsonGraph{
+		g:       g,
+		nodes:   ids,
+		removed: make(map[int64]bool, len(ids)),
+		blocked: make(map[int64]bool, len(ids)),
+		bSet:    make(map[int64]map[int64]bool, len(ids)),
+	}
+}
+
+// run drives the outer loop of Johnson's algorithm: for each remaining
+// vertex s, in ascending order, find the strongly connected component of
+// the subgraph induced by the not-yet-removed vertices that contains s,
+// search it for circuits rooted at s, then remove s and continue with the
+// next vertex.
+func (jg *johnsonGraph) run(emit func([]int64)) {
+	for _, s := range jg.nodes {
+		if jg.removed[s] {
+			continue
+		}
+
+		scc := jg.sccContaining(s)
+		sccSet := make(map[int64]bool, len(scc))
+		for _, v := range scc {
+			sccSet[v] = true
+			jg.blocked[v] = false
+			jg.bSet[v] = make(map[int64]bool)
+		}
+
+		jg.stack = jg.stack[:0]
+		jg.circuit(s, s, sccSet, emit)
+
+		jg.removed[s] = true
+	}
+}
+
+// sccContaining computes the strongly connected components of the
+// subgraph induced by the vertices not yet removed, using Tarjan's
+// algorithm, and returns the component containing s.
+func (jg *johnsonGraph) sccContaining(s int64) []int64 {
+	remaining := make(map[int64]bool, len(jg.nodes))
+	for _, id := range jg.nodes {
+		if !jg.removed[id] {
+			remaining[id] = true
+		}
+	}
+
+	index := make(map[int64]int, len(remaining))
+	low := make(map[int64]int, len(remaining))
+	onStack := make(map[int64]bool, len(remaining))
+	var idxStack []int64
+	counter := 0
+	var sccs [][]int64
+
+	var strongconnect func(v int64)
+	strongconnect = func(v int64) {
+		index[v] = counter
+		low[v] = counter
+		counter++
+		idxStack = append(idxStack, v)
+		onStack[v] = true
+
+		for _, wn := range graph.NodesOf(jg.g.From(v)) {
+			w := wn.ID()
+			if !remaining[w] {
+				continue
+			}
+			if _, ok := index[w]; !ok {
+				strongconnect(w)
+				if low[w] < low[v] {
+					low[v] = low[w]
+				}
+			} else if onStack[w] && index[w] < low[v] {
+				low[v] = index[w]
+			}
+		}
+
+		if low[v] == index[v] {
+			var comp []int64
+			for {
+				n := len(idxStack) - 1
+				w := idxStack[n]
+				idxStack = idxStack[:n]
+				onStack[w] = false
+				comp = append(comp, w)
+				if w == v {
+					break
+				}
+			}
+			sccs = append(sccs, comp)
+		}
+	}
+
+	for _, id := range jg.nodes {
+		if !remaining[id] {
+			continue
+		}
+		if _, ok := index[id]; !ok {
+			strongconnect(id)
+		}
+	}
+
+	for _, comp := range sccs {
+		for _, v := range comp {
+			if v == s {
+				return comp
+			}
+		}
+	}
+	// s is always present in remaining and therefore in some component.
+	return nil
+}
+
+// circuit is Johnson's CIRCUIT(v) procedure: it extends the current
+// search stack through v, looking for a path back to s within the current
+// component, reports every circuit found, and blocks or unblocks vertices
+// according to whether the search through v found anything.
+func (jg *johnsonGraph) circuit(v, s int64, sccSet map[int64]bool, emit func([]int64)) bool {
+	found := false
+	jg.stack = append(jg.stack, v)
+	jg.blocked[v] = true
+
+	to := graph.NodesOf(jg.g.From(v))
+	sort.Slice(to, func(i, j int) bool { return to[i].ID() < to[j].ID() })
+	for _, wn := range to {
+		w := wn.ID()
+		if !sccSet[w] {
+			continue
+		}
+		if w == s {
+			circuitIDs := make([]int64, len(jg.stack)+1)
+			copy(circuitIDs, jg.stack)
+			circuitIDs[len(jg.stack)] = s
+			emit(circuitIDs)
+			found = true
+		} else if !jg.blocked[w] {
+			if jg.circuit(w, s, sccSet, emit) {
+				found = true
+			}
+		}
+	}
+
+	if found {
+		jg.unblock(v)
+	} else {
+		for _, wn := range to {
+			w := wn.ID()
+			if !sccSet[w] {
+				continue
+			}
+			jg.bSet[w][v] = true
+		}
+	}
+
+	jg.stack = jg.stack[:len(jg.stack)-1]
+	return found
+}
+
+// unblock is Johnson's UNBLOCK(u) procedure: it marks u as unblocked and
+// recursively unblocks every vertex that was waiting on u.
+func (jg *johnsonGraph) unblock(u int64) {
+	jg.blocked[u] = false
+	for w := range jg.bSet[u] {
+		delete(jg.bSet[u], w)
+		if jg.blocked[w] {
+			jg.unblock(w)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var directedCyclesInTests = []struct {
+	g    []intset
+	want [][]int64
+}{
+	{
+		// 0 -> 1 -> 2 -> 0 and 1 -> 2 -> 1 give two circuits, plus the
+		// self-loop on 2.
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(0, 1, 2),
+		},
+		want: [][]int64{
+			{0, 1, 2, 0},
+			{1, 2, 1},
+			{2, 2},
+		},
+	},
+	{
+		// No edges at all: no circuits.
+		g: []intset{
+			0: nil,
+			1: nil,
+		},
+		want: nil,
+	},
+}
+
+func TestDirectedCyclesIn(t *testing.T) {
+	for i, test := range directedCyclesInTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		cycles := DirectedCyclesIn(g)
+		var got [][]int64
+		for _, c := range cycles {
+			ids := make([]int64, len(c))
+			for j, n := range c {
+				ids[j] = n.ID()
+			}
+			got = append(got, ids)
+		}
+		sort.Sort(byFirstIDs(got))
+		want := append([][]int64(nil), test.want...)
+		sort.Sort(byFirstIDs(want))
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected johnson result for %d:\n\tgot: %#v\n\twant: %#v", i, got, want)
+		}
+	}
+}
+
+// byFirstIDs sorts circuits lexicographically so that test comparisons do
+// not depend on enumeration order.
+type byFirstIDs [][]int64
+
+func (s byFirstIDs) Len() int      { return len(s) }
+func (s byFirstIDs) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byFirstIDs) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
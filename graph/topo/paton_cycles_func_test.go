@@ -0,0 +1,56 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestUndirectedCyclesInFunc(t *testing.T) {
+	for i, test := range undirectedCyclesInTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		g.AddNode(simple.Node(-10))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		// Visiting every cycle and letting the callback always return
+		// true must find exactly as many cycles as UndirectedCyclesIn.
+		var n int
+		complete := UndirectedCyclesInFunc(g, func([]graph.Node) bool {
+			n++
+			return true
+		})
+		if !complete {
+			t.Errorf("test %d: expected UndirectedCyclesInFunc to complete", i)
+		}
+		if want := len(UndirectedCyclesIn(g)); n != want {
+			t.Errorf("test %d: got %d cycles from UndirectedCyclesInFunc, want %d", i, n, want)
+		}
+
+		// Stopping after the first cycle must report early exit and
+		// must not call visit again.
+		var calls int
+		complete = UndirectedCyclesInFunc(g, func([]graph.Node) bool {
+			calls++
+			return false
+		})
+		if complete {
+			t.Errorf("test %d: expected UndirectedCyclesInFunc to report early exit", i)
+		}
+		if want := 1; n > 0 && calls != want {
+			t.Errorf("test %d: got %d calls to visit after early exit, want %d", i, calls, want)
+		}
+	}
+}
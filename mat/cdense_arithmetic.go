@@ -12,6 +12,33 @@ func (m *CDense) Add(a, b CMatrix) {
 		panic(ErrShape)
 	}
 
+	// Specialize on DiagCDense operands so that the O(n) structure of the
+	// diagonal is exploited rather than falling through to the generic
+	// At-based loop below.
+	if da, aDiag := a.(*DiagCDense); aDiag {
+		if db, bDiag := b.(*DiagCDense); bDiag {
+			m.addDiagDiag(da, db)
+			return
+		}
+		m.addDenseDiag(b, da)
+		return
+	}
+	if db, bDiag := b.(*DiagCDense); bDiag {
+		m.addDenseDiag(a, db)
+		return
+	}
+
+	// Specialize on sparse operands so only the non-zero entries are
+	// touched beyond the initial O(r*c) copy of the dense operand.
+	if sa, ok := a.(sparseCMatrix); ok {
+		m.addDenseSparse(b, sa)
+		return
+	}
+	if sb, ok := b.(sparseCMatrix); ok {
+		m.addDenseSparse(a, sb)
+		return
+	}
+
 	aU, aTrans, _ := untransposeExtractCmplx(a)
 	bU, bTrans, _ := untransposeExtractCmplx(b)
 	m.reuseAsNonZeroed(ar, ac)
@@ -59,6 +86,28 @@ func (m *CDense) Sub(a, b CMatrix) {
 		panic(ErrShape)
 	}
 
+	if da, aDiag := a.(*DiagCDense); aDiag {
+		if db, bDiag := b.(*DiagCDense); bDiag {
+			m.subDiagDiag(da, db)
+			return
+		}
+		m.subDiagDense(da, b)
+		return
+	}
+	if db, bDiag := b.(*DiagCDense); bDiag {
+		m.subDenseDiag(a, db)
+		return
+	}
+
+	if sa, ok := a.(sparseCMatrix); ok {
+		m.subSparseDense(sa, b)
+		return
+	}
+	if sb, ok := b.(sparseCMatrix); ok {
+		m.subDenseSparse(a, sb)
+		return
+	}
+
 	aU, aTrans, _ := untransposeExtractCmplx(a)
 	bU, bTrans, _ := untransposeExtractCmplx(b)
 	m.reuseAsNonZeroed(ar, ac)
@@ -108,6 +157,26 @@ func (m *CDense) Mul(a, b CMatrix) {
 		panic(ErrShape)
 	}
 
+	if da, aDiag := a.(*DiagCDense); aDiag {
+		if db, bDiag := b.(*DiagCDense); bDiag {
+			m.mulDiagDiag(da, db)
+			return
+		}
+		m.mulDiagDense(da, b)
+		return
+	}
+	if db, bDiag := b.(*DiagCDense); bDiag {
+		m.mulDenseDiag(a, db)
+		return
+	}
+
+	if sa, ok := a.(*CsrCMatrix); ok {
+		if bd, ok := b.(*CDense); ok {
+			m.mulCsrDense(sa, bd)
+			return
+		}
+	}
+
 	aU, aTrans, _ := untransposeExtractCmplx(a)
 	bU, bTrans, _ := untransposeExtractCmplx(b)
 	m.reuseAsNonZeroed(ar, bc)
@@ -183,3 +252,211 @@ func (m *CDense) Scale(f complex128, a CMatrix) {
 		}
 	}
 }
+
+// MulElem performs element-wise multiplication of a and b, placing the
+// result in the receiver. MulElem will panic if the two matrices do not
+// have the same shape.
+func (m *CDense) MulElem(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+
+	aU, _, _ := untransposeExtractCmplx(a)
+	bU, _, _ := untransposeExtractCmplx(b)
+	m.reuseAsNonZeroed(ar, ac)
+
+	if arm, ok := a.(*CDense); ok {
+		if brm, ok := b.(*CDense); ok {
+			amat, bmat := arm.mat, brm.mat
+			if m != arm {
+				m.checkOverlap(amat)
+			}
+			if m != brm {
+				m.checkOverlap(bmat)
+			}
+			for ja, jb, jm := 0, 0, 0; ja < ar*amat.Stride; ja, jb, jm = ja+amat.Stride, jb+bmat.Stride, jm+m.mat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					m.mat.Data[i+jm] = v * bmat.Data[i+jb]
+				}
+			}
+			return
+		}
+	}
+
+	m.checkOverlapMatrix(aU)
+	m.checkOverlapMatrix(bU)
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, a.At(r, c)*b.At(r, c))
+		}
+	}
+}
+
+// DivElem performs element-wise division of a by b, placing the result in
+// the receiver. DivElem will panic if the two matrices do not have the
+// same shape.
+func (m *CDense) DivElem(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+
+	aU, _, _ := untransposeExtractCmplx(a)
+	bU, _, _ := untransposeExtractCmplx(b)
+	m.reuseAsNonZeroed(ar, ac)
+
+	if arm, ok := a.(*CDense); ok {
+		if brm, ok := b.(*CDense); ok {
+			amat, bmat := arm.mat, brm.mat
+			if m != arm {
+				m.checkOverlap(amat)
+			}
+			if m != brm {
+				m.checkOverlap(bmat)
+			}
+			for ja, jb, jm := 0, 0, 0; ja < ar*amat.Stride; ja, jb, jm = ja+amat.Stride, jb+bmat.Stride, jm+m.mat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					m.mat.Data[i+jm] = v / bmat.Data[i+jb]
+				}
+			}
+			return
+		}
+	}
+
+	m.checkOverlapMatrix(aU)
+	m.checkOverlapMatrix(bU)
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, a.At(r, c)/b.At(r, c))
+		}
+	}
+}
+
+// DiagView returns the diagonal of the receiver as a CDiagonal backed by
+// the same data: changes made through the returned value are reflected in
+// the receiver and vice versa.
+func (m *CDense) DiagView() CDiagonal {
+	n := min(m.Dims())
+	return &DiagCDense{
+		mat: cblas128.Vector{
+			N:    n,
+			Inc:  m.mat.Stride + 1,
+			Data: m.mat.Data[:(n-1)*(m.mat.Stride+1)+1],
+		},
+	}
+}
+
+// SetDiag copies the diagonal of v into the diagonal of the receiver,
+// leaving the off-diagonal elements of the receiver unchanged. SetDiag
+// will panic if v's diagonal length does not equal min(m.Dims()).
+func (m *CDense) SetDiag(v CDiagonal) {
+	n := min(m.Dims())
+	if v.Diag() != n {
+		panic(ErrShape)
+	}
+	dst := m.DiagView().(*DiagCDense)
+	if vr, ok := v.(*DiagCDense); ok {
+		cblas128.Copy(vr.mat, dst.mat)
+		return
+	}
+	for i := 0; i < n; i++ {
+		dst.setDiag(i, v.At(i, i))
+	}
+}
+
+// addDenseDiag adds the diagonal matrix d to dense, storing the result in
+// the receiver. Only the diagonal of the result differs from dense, so
+// when the receiver is dense itself this runs in O(n); otherwise the
+// O(n²) copy of dense into the receiver dominates.
+func (m *CDense) addDenseDiag(dense CMatrix, d *DiagCDense) {
+	n := d.mat.N
+	if m != dense {
+		m.reuseAsNonZeroed(n, n)
+		m.Copy(dense)
+	}
+	for i := 0; i < n; i++ {
+		m.set(i, i, m.at(i, i)+d.mat.Data[i*d.mat.Inc])
+	}
+}
+
+func (m *CDense) addDiagDiag(a, b *DiagCDense) {
+	n := a.mat.N
+	m.reuseAsNonZeroed(n, n)
+	m.Zero()
+	for i := 0; i < n; i++ {
+		m.set(i, i, a.mat.Data[i*a.mat.Inc]+b.mat.Data[i*b.mat.Inc])
+	}
+}
+
+func (m *CDense) subDenseDiag(dense CMatrix, d *DiagCDense) {
+	n := d.mat.N
+	if m != dense {
+		m.reuseAsNonZeroed(n, n)
+		m.Copy(dense)
+	}
+	for i := 0; i < n; i++ {
+		m.set(i, i, m.at(i, i)-d.mat.Data[i*d.mat.Inc])
+	}
+}
+
+func (m *CDense) subDiagDense(d *DiagCDense, dense CMatrix) {
+	n := d.mat.N
+	if m != dense {
+		// Fold the copy and the negation into a single pass over the
+		// n×n buffer, rather than a full Copy followed by a full Scale.
+		m.reuseAsNonZeroed(n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				m.set(i, j, -dense.At(i, j))
+			}
+		}
+	} else {
+		m.Scale(-1, m)
+	}
+	for i := 0; i < n; i++ {
+		m.set(i, i, m.at(i, i)+d.mat.Data[i*d.mat.Inc])
+	}
+}
+
+func (m *CDense) subDiagDiag(a, b *DiagCDense) {
+	n := a.mat.N
+	m.reuseAsNonZeroed(n, n)
+	m.Zero()
+	for i := 0; i < n; i++ {
+		m.set(i, i, a.mat.Data[i*a.mat.Inc]-b.mat.Data[i*b.mat.Inc])
+	}
+}
+
+func (m *CDense) mulDiagDense(d *DiagCDense, dense CMatrix) {
+	n, c := dense.Dims()
+	m.reuseAsNonZeroed(n, c)
+	for i := 0; i < n; i++ {
+		di := d.mat.Data[i*d.mat.Inc]
+		for j := 0; j < c; j++ {
+			m.set(i, j, di*dense.At(i, j))
+		}
+	}
+}
+
+func (m *CDense) mulDenseDiag(dense CMatrix, d *DiagCDense) {
+	r, n := dense.Dims()
+	m.reuseAsNonZeroed(r, n)
+	for j := 0; j < n; j++ {
+		dj := d.mat.Data[j*d.mat.Inc]
+		for i := 0; i < r; i++ {
+			m.set(i, j, dense.At(i, j)*dj)
+		}
+	}
+}
+
+func (m *CDense) mulDiagDiag(a, b *DiagCDense) {
+	n := a.mat.N
+	m.reuseAsNonZeroed(n, n)
+	m.Zero()
+	for i := 0; i < n; i++ {
+		m.set(i, i, a.mat.Data[i*a.mat.Inc]*b.mat.Data[i*b.mat.Inc])
+	}
+}
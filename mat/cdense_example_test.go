@@ -6,6 +6,7 @@ package mat_test
 
 import (
 	"fmt"
+	"log"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -61,115 +62,112 @@ func ExampleCDense_Sub() {
 	//     ⎣1  -3⎦
 }
 
-/*
-	func ExampleCDense_MulElem() {
-		// Initialize two matrices, a and b.
-		a := mat.NewCDense(2, 2, []complex128{
-			1, 2,
-			3, 4,
-		})
-		b := mat.NewCDense(2, 2, []complex128{
-			1, 2,
-			3, 4,
-		})
-
-		// Multiply the elements of a and b, placing the result into a.
-		a.MulElem(a, b)
-
-		// Print the result using the formatter.
-		fa := mat.Formatted(a, mat.Prefix("    "), mat.Squeeze())
-		fmt.Printf("a = %v", fa)
-
-		// Output:
-		//
-		// a = ⎡1   4⎤
-		//     ⎣9  16⎦
-	}
+func ExampleCDense_MulElem() {
+	// Initialize two matrices, a and b.
+	a := mat.NewCDense(2, 2, []complex128{
+		1, 2,
+		3, 4,
+	})
+	b := mat.NewCDense(2, 2, []complex128{
+		1, 2,
+		3, 4,
+	})
+
+	// Multiply the elements of a and b, placing the result into a.
+	a.MulElem(a, b)
 
-/*
-
-	func ExampleCDense_DivElem() {
-		// Initialize two matrices, a and b.
-		a := mat.NewCDense(2, 2, []complex128{
-			5, 10,
-			15, 20,
-		})
-		b := mat.NewCDense(2, 2, []complex128{
-			5, 5,
-			5, 5,
-		})
-
-		// Divide the elements of a by b, placing the result into a.
-		a.DivElem(a, b)
-
-		// Print the result using the formatter.
-		fa := mat.Formatted(a, mat.Prefix("    "), mat.Squeeze())
-		fmt.Printf("a = %v", fa)
-
-		// Output:
-		//
-		// a = ⎡1  2⎤
-		//     ⎣3  4⎦
+	// Print the result using the formatter.
+	//fa := mat.Formatted(a, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("a = %v", a)
+
+	// Output:
+	//
+	// a = ⎡1   4⎤
+	//     ⎣9  16⎦
+}
+
+func ExampleCDense_DivElem() {
+	// Initialize two matrices, a and b.
+	a := mat.NewCDense(2, 2, []complex128{
+		5, 10,
+		15, 20,
+	})
+	b := mat.NewCDense(2, 2, []complex128{
+		5, 5,
+		5, 5,
+	})
+
+	// Divide the elements of a by b, placing the result into a.
+	a.DivElem(a, b)
+
+	// Print the result using the formatter.
+	//fa := mat.Formatted(a, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("a = %v", a)
+
+	// Output:
+	//
+	// a = ⎡1  2⎤
+	//     ⎣3  4⎦
+}
+
+func ExampleCDense_Inverse() {
+	// Initialize a matrix A.
+	a := mat.NewCDense(2, 2, []complex128{
+		2, 1,
+		6, 4,
+	})
+
+	// Compute the inverse of A.
+	var aInv mat.CDense
+	err := aInv.Inverse(a)
+	if err != nil {
+		log.Fatalf("A is not invertible: %v", err)
 	}
 
-	func ExampleCDense_Inverse() {
-		// Initialize a matrix A.
-		a := mat.NewCDense(2, 2, []complex128{
-			2, 1,
-			6, 4,
-		})
-
-		// Compute the inverse of A.
-		var aInv mat.CDense
-		err := aInv.Inverse(a)
-		if err != nil {
-			log.Fatalf("A is not invertible: %v", err)
-		}
-
-		// Print the result using the formatter.
-		fa := mat.Formatted(&aInv, mat.Prefix("       "), mat.Squeeze())
-		fmt.Printf("aInv = %.2g\n\n", fa)
-
-		// Confirm that A * A^-1 = I.
-		var I mat.CDense
-		I.Mul(a, &aInv)
-		fi := mat.Formatted(&I, mat.Prefix("    "), mat.Squeeze())
-		fmt.Printf("I = %v\n\n", fi)
-
-		// The Inverse operation, however, should typically be avoided. If the
-		// goal is to solve a linear system
-		//  A * X = B,
-		// then the inverse is not needed and computing the solution as
-		// X = A^{-1} * B is slower and has worse stability properties than
-		// solving the original problem. In this case, the SolveVec method of
-		// VecCDense (if B is a vector) or Solve method of CDense (if B is a
-		// matrix) should be used instead of computing the Inverse of A.
-		b := mat.NewCDense(2, 2, []complex128{
-			2, 3,
-			1, 2,
-		})
-		var x mat.CDense
-		err = x.Solve(a, b)
-		if err != nil {
-			log.Fatalf("no solution: %v", err)
-		}
-
-		// Print the result using the formatter.
-		fx := mat.Formatted(&x, mat.Prefix("    "), mat.Squeeze())
-		fmt.Printf("x = %.1f", fx)
-
-		// Output:
-		//
-		// aInv = ⎡ 2  -0.5⎤
-		//        ⎣-3     1⎦
-		//
-		// I = ⎡1  0⎤
-		//     ⎣0  1⎦
-		//
-		// x = ⎡ 3.5   5.0⎤
-		//     ⎣-5.0  -7.0⎦
+	// Print the result using the formatter.
+	//fa := mat.Formatted(&aInv, mat.Prefix("       "), mat.Squeeze())
+	fmt.Printf("aInv = %v\n\n", aInv)
+
+	// Confirm that A * A^-1 = I.
+	var I mat.CDense
+	I.Mul(a, &aInv)
+	//fi := mat.Formatted(&I, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("I = %v\n\n", I)
+
+	// The Inverse operation, however, should typically be avoided. If the
+	// goal is to solve a linear system
+	//  A * X = B,
+	// then the inverse is not needed and computing the solution as
+	// X = A^{-1} * B is slower and has worse stability properties than
+	// solving the original problem. In this case, the SolveVec method of
+	// VecCDense (if B is a vector) or Solve method of CDense (if B is a
+	// matrix) should be used instead of computing the Inverse of A.
+	b := mat.NewCDense(2, 2, []complex128{
+		2, 3,
+		1, 2,
+	})
+	var x mat.CDense
+	err = x.Solve(a, b)
+	if err != nil {
+		log.Fatalf("no solution: %v", err)
 	}
-*/
+
+	// Print the result using the formatter.
+	//fx := mat.Formatted(&x, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("x = %v", x)
+
+	// Output:
+	//
+	// aInv = ⎡2  -0.5⎤
+	//        ⎣-3  1⎦
+	//
+	// I = ⎡1  0⎤
+	//     ⎣0  1⎦
+	//
+	// x = ⎡3.5  5⎤
+	//     ⎣-5  -7⎦
+}
+
 func ExampleCDense_Mul() {
 	// Initialize two matrices, a and b.
 	a := mat.NewCDense(2, 2, []complex128{
@@ -195,7 +193,7 @@ func ExampleCDense_Mul() {
 	//     ⎣ 0  0  4*1i⎦
 }
 
-/*func ExampleCDense_Exp() {
+func ExampleCDense_Exp() {
 	// Initialize a matrix a with some data.
 	a := mat.NewCDense(2, 2, []complex128{
 		1, 0,
@@ -207,8 +205,8 @@ func ExampleCDense_Mul() {
 	m.Exp(a)
 
 	// Print the result using the formatter.
-	fm := mat.Formatted(&m, mat.Prefix("    "), mat.Squeeze())
-	fmt.Printf("m = %4.2f", fm)
+	//fm := mat.Formatted(&m, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("m = %.2f", m)
 
 	// Output:
 	//
@@ -228,8 +226,8 @@ func ExampleCDense_Pow() {
 	m.Pow(a, 2)
 
 	// Print the result using the formatter.
-	fm := mat.Formatted(&m, mat.Prefix("    "), mat.Squeeze())
-	fmt.Printf("m = %v\n\n", fm)
+	//fm := mat.Formatted(&m, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("m = %v\n\n", m)
 
 	// Take the zeroth power of matrix a and place the result in n.
 	// We expect an identity matrix of the same size as matrix a.
@@ -237,8 +235,8 @@ func ExampleCDense_Pow() {
 	n.Pow(a, 0)
 
 	// Print the result using the formatter.
-	fn := mat.Formatted(&n, mat.Prefix("    "), mat.Squeeze())
-	fmt.Printf("n = %v", fn)
+	//fn := mat.Formatted(&n, mat.Prefix("    "), mat.Squeeze())
+	fmt.Printf("n = %v", n)
 
 	// Output:
 	//
@@ -247,7 +245,7 @@ func ExampleCDense_Pow() {
 	//
 	// n = ⎡1  0⎤
 	//     ⎣0  1⎦
-}*/
+}
 
 func ExampleCDense_Scale() {
 	// Initialize a matrix with some data.
@@ -0,0 +1,212 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "math"
+
+// complexPadeThreshold collects the algorithm 5.1 (Higham, 2005)
+// scaling threshold θ_m for each available Padé degree m.
+var complexPadeThreshold = map[int]float64{
+	3:  1.495585217958292e-2,
+	5:  2.539398330063230e-1,
+	7:  9.504178996162932e-1,
+	9:  2.097847961257068e0,
+	13: 5.371920351148152e0,
+}
+
+// complexPadeCoeffs collects the coefficients b_0...b_m of the [m/m] Padé
+// approximant numerator/denominator used by expmComplex, indexed by degree.
+var complexPadeCoeffs = map[int][]float64{
+	3: {120, 60, 12, 1},
+	5: {30240, 15120, 3360, 420, 30, 1},
+	7: {17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+	9: {17643225600, 8821612800, 2075673600, 302702400, 30270240,
+		2162160, 110880, 3960, 90, 1},
+	13: {64764752532480000, 32382376266240000, 7771770303897600,
+		1187353796428800, 129060195264000, 10559470521600,
+		670442572800, 33522128640, 1323241920, 40840800,
+		960960, 16380, 182, 1},
+}
+
+// Exp computes the matrix exponential of the square matrix a, e^a, using
+// the scaling-and-squaring algorithm with a [13/13] Padé approximant, or
+// the lowest of the [3/3], [5/5], [7/7] and [9/9] approximants whose
+// threshold bounds the 1-norm of a, described in
+//
+//	Nicholas J. Higham, "The Scaling and Squaring Method for the Matrix
+//	Exponential Revisited", SIAM J. Matrix Anal. Appl., 26(4) (2005),
+//	pp. 1179-1193.
+//
+// and stores the result into the receiver.
+//
+// Exp panics if a is not square, or if the internal Padé solve reports that
+// its system is singular, which should not happen for a well-formed a.
+func (m *CDense) Exp(a CMatrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+
+	var work CDense
+	work.CloneFrom(a)
+	n := r
+
+	normA := work.normOne()
+
+	// Find the lowest-degree Padé approximant whose scaling threshold
+	// bounds the (possibly already small) norm of a, falling back to the
+	// highest degree with repeated squaring if a is large.
+	degrees := []int{3, 5, 7, 9}
+	var s int
+	deg := 13
+	for _, cand := range degrees {
+		if normA <= complexPadeThreshold[cand] {
+			deg = cand
+			break
+		}
+	}
+	if deg == 13 && normA > complexPadeThreshold[13] {
+		s = int(math.Ceil(math.Log2(normA / complexPadeThreshold[13])))
+		if s < 0 {
+			s = 0
+		}
+		if s > 0 {
+			work.Scale(complex(1/math.Pow(2, float64(s)), 0), &work)
+		}
+	}
+
+	if err := m.padeApprox(&work, n, deg); err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < s; i++ {
+		var sq CDense
+		sq.Mul(m, m)
+		m.CloneFrom(&sq)
+	}
+}
+
+// padeApprox evaluates the [deg/deg] Padé approximant of e^a at a, storing
+// the result in the receiver. It caches the even powers of a (a^2, a^4, ...)
+// so that each is computed only once regardless of how many terms of the
+// approximant use it.
+func (m *CDense) padeApprox(a *CDense, n, deg int) error {
+	b := complexPadeCoeffs[deg]
+
+	pow := make([]*CDense, deg/2+1)
+	ident := NewCDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		ident.set(i, i, 1)
+	}
+	pow[0] = ident
+	for i := 1; i < len(pow); i++ {
+		p := new(CDense)
+		p.Mul(pow[i-1], a)
+		p.Mul(p, a)
+		pow[i] = p
+	}
+
+	u := new(CDense)
+	v := new(CDense)
+	u.reuseAsNonZeroed(n, n)
+	v.reuseAsNonZeroed(n, n)
+
+	// U = a * (b[deg]*a^(deg-1) + b[deg-2]*a^(deg-3) + ... + b[1]*I)
+	// V =      b[deg-1]*a^(deg-1) + b[deg-3]*a^(deg-3) + ... + b[0]*I
+	uInner := new(CDense)
+	uInner.reuseAsNonZeroed(n, n)
+	for i := range uInner.mat.Data {
+		uInner.mat.Data[i] = 0
+	}
+	for i := range v.mat.Data {
+		v.mat.Data[i] = 0
+	}
+	for k := deg; k >= 1; k -= 2 {
+		var term CDense
+		term.Scale(complex(b[k], 0), pow[(k-1)/2])
+		uInner.Add(uInner, &term)
+	}
+	for k := deg - 1; k >= 0; k -= 2 {
+		var term CDense
+		term.Scale(complex(b[k], 0), pow[k/2])
+		v.Add(v, &term)
+	}
+	u.Mul(a, uInner)
+
+	var vPlusU, vMinusU CDense
+	vPlusU.Add(v, u)
+	vMinusU.Sub(v, u)
+	// V - U is the denominator of the Padé approximant; it is I plus
+	// terms of order a^2 or higher, so for the scaled a passed in by Exp
+	// (norm bounded by complexPadeThreshold) it is always well-conditioned
+	// in exact arithmetic. Solve can still fail on a pathological floating
+	// point draw, so surface that rather than returning a silently wrong
+	// result.
+	return m.Solve(&vMinusU, &vPlusU)
+}
+
+// Pow computes the n-th power of the square matrix a, a^n, and stores the
+// result into the receiver.
+//
+//	n == 0: the result is the identity matrix, regardless of a.
+//	n < 0:  the result is (a^-1)^(-n); it panics if a is singular.
+//	n > 0:  the result is computed by binary exponentiation, reusing
+//	        workspace across the O(log n) squarings.
+//
+// Pow panics if a is not square.
+func (m *CDense) Pow(a CMatrix, n int) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+
+	if n == 0 {
+		m.reuseAsNonZeroed(r, r)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = 0
+		}
+		for i := 0; i < r; i++ {
+			m.set(i, i, 1)
+		}
+		return
+	}
+
+	if n < 0 {
+		var inv CDense
+		if err := inv.Inverse(a); err != nil {
+			panic(err)
+		}
+		m.Pow(&inv, -n)
+		return
+	}
+
+	// Binary exponentiation: result := base^n, squaring base and halving
+	// n each iteration, multiplying the running result in whenever the
+	// current bit of n is set.
+	var base CDense
+	base.CloneFrom(a)
+	var result CDense
+	result.reuseAsNonZeroed(r, r)
+	for i := range result.mat.Data {
+		result.mat.Data[i] = 0
+	}
+	for i := 0; i < r; i++ {
+		result.set(i, i, 1)
+	}
+
+	var tmp CDense
+	for n > 0 {
+		if n&1 != 0 {
+			tmp.Mul(&result, &base)
+			result.CloneFrom(&tmp)
+		}
+		n >>= 1
+		if n > 0 {
+			tmp.Mul(&base, &base)
+			base.CloneFrom(&tmp)
+		}
+	}
+	m.CloneFrom(&result)
+}
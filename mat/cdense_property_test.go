@@ -0,0 +1,124 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat_test
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/mat/mattest"
+)
+
+const propertyTolerance = 1e-9
+
+// equalApprox reports whether a and b have the same shape and agree
+// element-wise to within propertyTolerance.
+func equalApprox(a, b mat.CMatrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if cmplx.Abs(a.At(i, j)-b.At(i, j)) > propertyTolerance {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// runProperty draws n random rows×cols matrices with the given seed and
+// checks the property. On failure it shrinks the failing matrix before
+// reporting it, so the test output is easy to read.
+func runProperty(t *testing.T, seed int64, rows, cols int, property func(a *mat.CDense) bool) {
+	t.Helper()
+	gen := mattest.Uniform(mattest.Seed(seed), 5)
+	for i := 0; i < 20; i++ {
+		a := mattest.RandCDense(rows, cols, gen)
+		if property(a) {
+			continue
+		}
+		shrunk := mattest.Shrink(a, func(m *mat.CDense) (ok bool) {
+			// A shrunk shape that the property cannot take (it panics)
+			// is not a counterexample.
+			defer func() {
+				if recover() != nil {
+					ok = true
+				}
+			}()
+			return property(m)
+		})
+		t.Fatalf("property violated for seed %d, case %d; shrunk counterexample:\n%v", seed, i, mat.Formatted(shrunk))
+	}
+}
+
+func TestPropertyAddCommutes(t *testing.T) {
+	t.Parallel()
+	genB := mattest.Uniform(mattest.Seed(2), 5)
+	runProperty(t, 1, 4, 4, func(a *mat.CDense) bool {
+		b := mattest.RandCDense(4, 4, genB)
+		var ab, ba mat.CDense
+		ab.Add(a, b)
+		ba.Add(b, a)
+		return equalApprox(&ab, &ba)
+	})
+}
+
+func TestPropertyMulAssociates(t *testing.T) {
+	t.Parallel()
+	genB := mattest.Uniform(mattest.Seed(4), 2)
+	genC := mattest.Uniform(mattest.Seed(5), 2)
+	runProperty(t, 3, 3, 3, func(a *mat.CDense) bool {
+		b := mattest.RandCDense(3, 3, genB)
+		c := mattest.RandCDense(3, 3, genC)
+		var bc, abc1 mat.CDense
+		bc.Mul(b, c)
+		abc1.Mul(a, &bc)
+
+		var ab, abc2 mat.CDense
+		ab.Mul(a, b)
+		abc2.Mul(&ab, c)
+
+		return equalApprox(&abc1, &abc2)
+	})
+}
+
+func TestPropertyConjTransposeInvolution(t *testing.T) {
+	t.Parallel()
+	runProperty(t, 6, 4, 4, func(a *mat.CDense) bool {
+		var aHH mat.CDense
+		aHH.CloneFrom(a.H().H())
+		return equalApprox(&aHH, a)
+	})
+}
+
+func TestPropertyScaleByConjugateReciprocalsIsIdentity(t *testing.T) {
+	t.Parallel()
+	runProperty(t, 7, 4, 4, func(a *mat.CDense) bool {
+		var scaled, back mat.CDense
+		scaled.Scale(-1i, a)
+		back.Scale(1i, &scaled)
+		return equalApprox(&back, a)
+	})
+}
+
+func TestPropertySolveRecoversX(t *testing.T) {
+	t.Parallel()
+	genX := mattest.Uniform(mattest.Seed(9), 5)
+	runProperty(t, 8, 4, 4, func(a *mat.CDense) bool {
+		x := mattest.RandCDense(4, 4, genX)
+		var b, got mat.CDense
+		b.Mul(a, x)
+		if err := got.Solve(a, &b); err != nil {
+			// A singular draw is not a counterexample; treat it as
+			// vacuously satisfying the property.
+			return true
+		}
+		return equalApprox(&got, x)
+	})
+}
@@ -5,6 +5,9 @@
 package mat
 
 import (
+	"math"
+	"math/cmplx"
+
 	"gonum.org/v1/gonum/blas/cblas128"
 )
 
@@ -179,7 +182,7 @@ func (d *DiagCDense) IsEmpty() bool {
 // Trace returns the trace of the matrix.
 //
 // Trace will panic with ErrZeroLength if the matrix has zero size.
-/*func (d *DiagCDense) Trace() complex128 {
+func (d *DiagCDense) Trace() complex128 {
 	if d.IsEmpty() {
 		panic(ErrZeroLength)
 	}
@@ -189,7 +192,7 @@ func (d *DiagCDense) IsEmpty() bool {
 		tr += rb.Data[rb.KL+i*rb.Stride]
 	}
 	return tr
-}*/
+}
 
 // Norm returns the specified norm of the receiver. Valid norms are:
 //
@@ -199,8 +202,8 @@ func (d *DiagCDense) IsEmpty() bool {
 //
 // Norm will panic with ErrNormOrder if an illegal norm is specified and with
 // ErrZeroLength if the receiver has zero size.
-func (d *DiagCDense) Norm(norm complex128) complex128 {
-	/*if d.IsEmpty() {
+func (d *DiagCDense) Norm(norm float64) float64 {
+	if d.IsEmpty() {
 		panic(ErrZeroLength)
 	}
 	switch norm {
@@ -208,9 +211,30 @@ func (d *DiagCDense) Norm(norm complex128) complex128 {
 		panic(ErrNormOrder)
 	case 1, math.Inf(1):
 		imax := cblas128.Iamax(d.mat)
-		return cmplx.Abs(d.at(imax, imax))
+		// Iamax ranks elements by |Re|+|Im| rather than by modulus, so
+		// its pick is only a starting point for the largest modulus.
+		max := cmplx.Abs(d.mat.Data[imax*d.mat.Inc])
+		for i := 0; i < d.mat.N; i++ {
+			if v := cmplx.Abs(d.mat.Data[i*d.mat.Inc]); v > max {
+				max = v
+			}
+		}
+		return max
 	case 2:
 		return cblas128.Nrm2(d.mat)
-	}*/
-	return 0
+	}
+}
+
+// SetDiag sets the diagonal element at i to v. SetDiag will panic if i is
+// out of range.
+func (d *DiagCDense) SetDiag(i int, v complex128) {
+	if i < 0 || i >= d.mat.N {
+		panic(ErrRowAccess)
+	}
+	d.setDiag(i, v)
+}
+
+// setDiag sets the diagonal element at i to v without bounds checking.
+func (d *DiagCDense) setDiag(i int, v complex128) {
+	d.mat.Data[i*d.mat.Inc] = v
 }
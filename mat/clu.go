@@ -0,0 +1,421 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// CLU is a type for creating and using the LU factorization of a complex
+// square matrix.
+type CLU struct {
+	lu   *CDense
+	piv  []int
+	cond float64
+}
+
+// Factorize computes the LU factorization of the square matrix a and stores
+// the result. The factorization is
+//
+//	A = P * L * U
+//
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is an upper triangular matrix. L and U are stored together in the
+// receiver's internal matrix, and P is stored implicitly as a sequence of
+// row interchanges.
+//
+// Factorize panics if a is not square.
+func (lu *CLU) Factorize(a CMatrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if lu.lu == nil {
+		lu.lu = NewCDense(r, r, nil)
+	} else {
+		lu.lu.Reset()
+		lu.lu.reuseAsNonZeroed(r, r)
+	}
+	lu.lu.Copy(a)
+	if cap(lu.piv) < r {
+		lu.piv = make([]int, r)
+	} else {
+		lu.piv = lu.piv[:r]
+	}
+	anorm := lu.lu.normOne()
+	lu.computeLU()
+	lu.updateCond(anorm)
+}
+
+// Cond returns an estimate of the condition number of the factorized
+// matrix, as computed by updateCond during the most recent call to
+// Factorize.
+func (lu *CLU) Cond() float64 {
+	if lu.lu == nil || lu.lu.IsEmpty() {
+		panic(ErrShape)
+	}
+	return lu.cond
+}
+
+// normOne returns the 1-norm (maximum absolute column sum) of the receiver.
+func (m *CDense) normOne() float64 {
+	r, c := m.Dims()
+	var max float64
+	for j := 0; j < c; j++ {
+		var sum float64
+		for i := 0; i < r; i++ {
+			sum += cmplx.Abs(m.at(i, j))
+		}
+		if sum > max {
+			max = sum
+		}
+	}
+	return max
+}
+
+// computeLU performs an unblocked, right-looking LU factorization with
+// partial pivoting in place on lu.lu, recording the row interchanges used
+// at each step in lu.piv following the usual LAPACK Getrf convention:
+// piv[k] is the index of the row swapped with row k during step k (or k
+// itself if no swap was required).
+func (lu *CLU) computeLU() {
+	a := lu.lu.mat
+	n := a.Rows
+	for k := 0; k < n; k++ {
+		p := k
+		pMax := cmplx.Abs(a.Data[k*a.Stride+k])
+		for i := k + 1; i < n; i++ {
+			if v := cmplx.Abs(a.Data[i*a.Stride+k]); v > pMax {
+				p, pMax = i, v
+			}
+		}
+		lu.piv[k] = p
+		if p != k {
+			rowK := a.Data[k*a.Stride : k*a.Stride+n]
+			rowP := a.Data[p*a.Stride : p*a.Stride+n]
+			for j := range rowK {
+				rowK[j], rowP[j] = rowP[j], rowK[j]
+			}
+		}
+		pivot := a.Data[k*a.Stride+k]
+		if pivot == 0 {
+			// The matrix is singular; skip elimination for this column.
+			// The zero on the diagonal of U makes updateCond report an
+			// infinite condition number, so later solves return a
+			// Condition error alongside a result that is not meaningful.
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			a.Data[i*a.Stride+k] /= pivot
+			factor := a.Data[i*a.Stride+k]
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a.Data[i*a.Stride+j] -= factor * a.Data[k*a.Stride+j]
+			}
+		}
+	}
+}
+
+// updateCond sets lu.cond from anorm, the 1-norm of the original matrix, and
+// the smallest pivot magnitude found on the diagonal of U. This is a cheap
+// estimate of the condition number — it is not as accurate as forming
+// inv(A) explicitly, but it is sufficient to flag singular and
+// near-singular systems.
+func (lu *CLU) updateCond(anorm float64) {
+	a := lu.lu.mat
+	n := a.Rows
+	minDiag := cmplx.Abs(a.Data[0])
+	for i := 1; i < n; i++ {
+		if d := cmplx.Abs(a.Data[i*a.Stride+i]); d < minDiag {
+			minDiag = d
+		}
+	}
+	if minDiag == 0 {
+		lu.cond = math.Inf(1)
+		return
+	}
+	lu.cond = anorm / minDiag
+}
+
+// permuteRowsForward applies the row interchanges recorded in lu.piv, in
+// the order they were recorded, to x.
+func (lu *CLU) permuteRowsForward(x cblas128.General) {
+	n := lu.lu.mat.Rows
+	for k := 0; k < n; k++ {
+		p := lu.piv[k]
+		if p == k {
+			continue
+		}
+		rowK := x.Data[k*x.Stride : k*x.Stride+x.Cols]
+		rowP := x.Data[p*x.Stride : p*x.Stride+x.Cols]
+		for j := range rowK {
+			rowK[j], rowP[j] = rowP[j], rowK[j]
+		}
+	}
+}
+
+// permuteRowsBackward undoes permuteRowsForward by applying the row
+// interchanges in reverse order.
+func (lu *CLU) permuteRowsBackward(x cblas128.General) {
+	n := lu.lu.mat.Rows
+	for k := n - 1; k >= 0; k-- {
+		p := lu.piv[k]
+		if p == k {
+			continue
+		}
+		rowK := x.Data[k*x.Stride : k*x.Stride+x.Cols]
+		rowP := x.Data[p*x.Stride : p*x.Stride+x.Cols]
+		for j := range rowK {
+			rowK[j], rowP[j] = rowP[j], rowK[j]
+		}
+	}
+}
+
+// forwardSubstUnitLower solves L·X = B in place, where L is the unit lower
+// triangular factor stored in lu.lu and B is given by x on entry.
+func (lu *CLU) forwardSubstUnitLower(x cblas128.General) {
+	a := lu.lu.mat
+	n := a.Rows
+	for i := 1; i < n; i++ {
+		for j := 0; j < x.Cols; j++ {
+			var sum complex128
+			for k := 0; k < i; k++ {
+				sum += a.Data[i*a.Stride+k] * x.Data[k*x.Stride+j]
+			}
+			x.Data[i*x.Stride+j] -= sum
+		}
+	}
+}
+
+// backSubstUpper solves U·X = B in place, where U is the upper triangular
+// factor stored in lu.lu and B is given by x on entry.
+func (lu *CLU) backSubstUpper(x cblas128.General) {
+	a := lu.lu.mat
+	n := a.Rows
+	for i := n - 1; i >= 0; i-- {
+		for j := 0; j < x.Cols; j++ {
+			sum := x.Data[i*x.Stride+j]
+			for k := i + 1; k < n; k++ {
+				sum -= a.Data[i*a.Stride+k] * x.Data[k*x.Stride+j]
+			}
+			x.Data[i*x.Stride+j] = sum / a.Data[i*a.Stride+i]
+		}
+	}
+}
+
+// backSubstUpperH solves Uᴴ·X = B in place.
+func (lu *CLU) backSubstUpperH(x cblas128.General) {
+	a := lu.lu.mat
+	n := a.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < x.Cols; j++ {
+			sum := x.Data[i*x.Stride+j]
+			for k := 0; k < i; k++ {
+				sum -= cmplx.Conj(a.Data[k*a.Stride+i]) * x.Data[k*x.Stride+j]
+			}
+			x.Data[i*x.Stride+j] = sum / cmplx.Conj(a.Data[i*a.Stride+i])
+		}
+	}
+}
+
+// forwardSubstUnitLowerH solves Lᴴ·X = B in place.
+func (lu *CLU) forwardSubstUnitLowerH(x cblas128.General) {
+	a := lu.lu.mat
+	n := a.Rows
+	for i := n - 1; i >= 0; i-- {
+		for j := 0; j < x.Cols; j++ {
+			sum := x.Data[i*x.Stride+j]
+			for k := i + 1; k < n; k++ {
+				sum -= cmplx.Conj(a.Data[k*a.Stride+i]) * x.Data[k*x.Stride+j]
+			}
+			x.Data[i*x.Stride+j] = sum
+		}
+	}
+}
+
+// undoColumnPivots undoes the row interchanges recorded in lu.piv by
+// applying them as column interchanges, in reverse order. This is the step
+// used by InverseTo to turn inv(P·A) into inv(A) = inv(P·A)·P.
+func (lu *CLU) undoColumnPivots(m cblas128.General) {
+	n := lu.lu.mat.Rows
+	for k := n - 1; k >= 0; k-- {
+		p := lu.piv[k]
+		if p == k {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			m.Data[i*m.Stride+k], m.Data[i*m.Stride+p] = m.Data[i*m.Stride+p], m.Data[i*m.Stride+k]
+		}
+	}
+}
+
+// SolveTo solves a system of linear equations using the LU factorization
+// computed by a preceding call to Factorize, storing the result into x. If
+// trans is true, SolveTo solves Aᴴ·X = B instead of A·X = B.
+//
+// The receiver x is resized to be n×bc, where n is the order of the
+// factorized matrix and bc is the number of columns of b.
+//
+// If the factorized matrix is singular or near-singular, a Condition error
+// is returned. See the documentation for Condition for more information.
+func (lu *CLU) SolveTo(x *CDense, trans bool, b CMatrix) error {
+	if lu.lu == nil || lu.lu.IsEmpty() {
+		panic(ErrShape)
+	}
+	n := lu.lu.mat.Rows
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	x.reuseAsNonZeroed(n, bc)
+	if x != b {
+		x.Copy(b)
+	}
+	if !trans {
+		lu.permuteRowsForward(x.mat)
+		lu.forwardSubstUnitLower(x.mat)
+		lu.backSubstUpper(x.mat)
+	} else {
+		lu.backSubstUpperH(x.mat)
+		lu.forwardSubstUnitLowerH(x.mat)
+		lu.permuteRowsBackward(x.mat)
+	}
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
+
+// InverseTo computes the inverse of the factorized matrix and stores the
+// result into m, following the LAPACK Getri approach: form inv(U) by back
+// substitution, solve X·L = inv(U) for X, and then undo the row pivoting
+// applied during factorization by permuting the columns of X.
+//
+// If the factorized matrix is singular or near-singular, a Condition error
+// is returned, though the (meaningless) result is still stored into m.
+func (lu *CLU) InverseTo(m *CDense) error {
+	if lu.lu == nil || lu.lu.IsEmpty() {
+		panic(ErrShape)
+	}
+	n := lu.lu.mat.Rows
+	m.reuseAsNonZeroed(n, n)
+
+	a := lu.lu.mat
+	minv := m.mat
+	for j := 0; j < n; j++ {
+		ujj := a.Data[j*a.Stride+j]
+		if ujj == 0 {
+			continue
+		}
+		minv.Data[j*minv.Stride+j] = 1 / ujj
+		for i := j - 1; i >= 0; i-- {
+			var sum complex128
+			for k := i + 1; k <= j; k++ {
+				sum += a.Data[i*a.Stride+k] * minv.Data[k*minv.Stride+j]
+			}
+			minv.Data[i*minv.Stride+j] = -sum / a.Data[i*a.Stride+i]
+		}
+		for i := j + 1; i < n; i++ {
+			minv.Data[i*minv.Stride+j] = 0
+		}
+	}
+
+	// Solve X·L = inv(U) for X, working from the penultimate column
+	// backwards since L is unit lower triangular.
+	for k := n - 2; k >= 0; k-- {
+		for i := 0; i < n; i++ {
+			var sum complex128
+			for j := k + 1; j < n; j++ {
+				sum += minv.Data[i*minv.Stride+j] * a.Data[j*a.Stride+k]
+			}
+			minv.Data[i*minv.Stride+k] -= sum
+		}
+	}
+
+	lu.undoColumnPivots(minv)
+
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
+
+// Solve solves the system of linear equations
+//
+//	A·X = B
+//
+// using the LU factorization of the square matrix a, storing the result
+// into the receiver. If a is singular or near-singular, a Condition error
+// is returned. See the documentation for Condition for more information.
+//
+// Solve should be preferred over computing Inverse and multiplying by the
+// inverse explicitly: it is both faster and numerically more stable.
+func (m *CDense) Solve(a, b CMatrix) error {
+	var lu CLU
+	lu.Factorize(a)
+	return lu.SolveTo(m, false, b)
+}
+
+// Inverse computes the inverse of the square matrix a, storing the result
+// into the receiver. If a is singular or near-singular, an error is
+// returned and the contents of the receiver are not meaningful.
+//
+// Computing the inverse of a matrix is used relatively rarely in numerical
+// applications. Solve is used far more often, and should be preferred
+// where possible: it is both faster and more numerically stable than
+// computing the Inverse and multiplying by it.
+func (m *CDense) Inverse(a CMatrix) error {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	var lu CLU
+	lu.Factorize(a)
+	return lu.InverseTo(m)
+}
+
+// SolveVec solves the system of linear equations
+//
+//	A·x = b
+//
+// using the LU factorization of the square matrix a, storing the result
+// into the receiver. If a is singular or near-singular, a Condition error
+// is returned. SolveVec mirrors CDense.Solve for the vector right-hand-side
+// case; see its documentation for the Inverse-versus-Solve caveat.
+func (v *VecCDense) SolveVec(a CMatrix, b CVector) error {
+	var lu CLU
+	lu.Factorize(a)
+	return lu.SolveVecTo(v, false, b)
+}
+
+// SolveVecTo solves a system of linear equations using the LU factorization
+// computed by a preceding call to Factorize, storing the result into x. If
+// trans is true, SolveVecTo solves Aᴴ·x = b instead of A·x = b.
+func (lu *CLU) SolveVecTo(x *VecCDense, trans bool, b CVector) error {
+	if lu.lu == nil || lu.lu.IsEmpty() {
+		panic(ErrShape)
+	}
+	n := lu.lu.mat.Rows
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	var bm CDense
+	bm.reuseAsNonZeroed(n, 1)
+	for i := 0; i < n; i++ {
+		bm.set(i, 0, b.AtVec(i))
+	}
+	var xm CDense
+	err := lu.SolveTo(&xm, trans, &bm)
+	x.reuseAsNonZeroed(n)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, xm.at(i, 0))
+	}
+	return err
+}
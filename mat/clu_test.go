@@ -0,0 +1,114 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat_test
+
+import (
+	"errors"
+	"math/cmplx"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCLUSolveSingular(t *testing.T) {
+	t.Parallel()
+	// The second row is a multiple of the first, so a is exactly singular.
+	a := mat.NewCDense(2, 2, []complex128{
+		1, 2,
+		2, 4,
+	})
+	b := mat.NewCDense(2, 1, []complex128{1, 2})
+
+	var x mat.CDense
+	err := x.Solve(a, b)
+	if err == nil {
+		t.Fatal("Solve of a singular matrix: got nil error, want a Condition error")
+	}
+	var cond mat.Condition
+	if !errors.As(err, &cond) {
+		t.Fatalf("Solve of a singular matrix: got error of type %T, want mat.Condition", err)
+	}
+}
+
+func TestCLUSolveToShapeMismatch(t *testing.T) {
+	t.Parallel()
+	a := mat.NewCDense(2, 2, []complex128{1, 0, 0, 1})
+	b := mat.NewCDense(3, 1, []complex128{1, 2, 3})
+
+	var lu mat.CLU
+	lu.Factorize(a)
+
+	var x mat.CDense
+	panicked := panics(func() { lu.SolveTo(&x, false, b) })
+	if !panicked {
+		t.Error("SolveTo with mismatched row counts: expected a panic")
+	}
+}
+
+func TestCLUSolveConjTranspose(t *testing.T) {
+	t.Parallel()
+	// a is not Hermitian, so solving Aᴴ·x = b exercises a genuinely
+	// different system than A·x = b.
+	a := mat.NewCDense(2, 2, []complex128{
+		2 + 1i, 1,
+		0, 3 - 2i,
+	})
+	x := mat.NewCDense(2, 1, []complex128{1 + 1i, -2i})
+
+	var b mat.CDense
+	b.Mul(a.H(), x)
+
+	var lu mat.CLU
+	lu.Factorize(a)
+
+	var got mat.CDense
+	if err := lu.SolveTo(&got, true, &b); err != nil {
+		t.Fatalf("SolveTo(trans=true): unexpected error: %v", err)
+	}
+
+	r, c := got.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if diff := cmplx.Abs(got.At(i, j) - x.At(i, j)); diff > 1e-10 {
+				t.Errorf("SolveTo(trans=true): at (%d, %d) got %v, want %v", i, j, got.At(i, j), x.At(i, j))
+			}
+		}
+	}
+}
+
+func TestVecCDenseSolveVec(t *testing.T) {
+	t.Parallel()
+	a := mat.NewCDense(3, 3, []complex128{
+		4, 1, 0,
+		1, 3, 1i,
+		0, -1i, 2,
+	})
+	x := mat.NewVecCDense(3, []complex128{1, 2i, -1})
+
+	var b mat.VecCDense
+	b.MulVec(a, x)
+
+	var got mat.VecCDense
+	if err := got.SolveVec(a, &b); err != nil {
+		t.Fatalf("SolveVec: unexpected error: %v", err)
+	}
+
+	for i := 0; i < x.Len(); i++ {
+		if diff := cmplx.Abs(got.AtVec(i) - x.AtVec(i)); diff > 1e-10 {
+			t.Errorf("SolveVec: at %d got %v, want %v", i, got.AtVec(i), x.AtVec(i))
+		}
+	}
+}
+
+// panics reports whether calling f panics.
+func panics(f func()) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = true
+		}
+	}()
+	f()
+	return false
+}
@@ -0,0 +1,406 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"sort"
+)
+
+// sparseCMatrix is implemented by the sparse complex matrix types in this
+// file. rowIter calls f once for every explicitly stored entry, in an
+// order convenient for the implementing type; it is the basis for the
+// O(nnz) fast paths in CDense.Add and CDense.Sub.
+type sparseCMatrix interface {
+	CMatrix
+	rowIter(f func(i, j int, v complex128))
+}
+
+// CooCMatrix is a complex sparse matrix held in coordinate (triplet)
+// format: entries are stored as independent (row, column, value) triples
+// in the order they were added. Duplicate (row, column) pairs are
+// permitted; they are summed when the matrix is converted to a compressed
+// format such as CsrCMatrix or CscCMatrix.
+//
+// CooCMatrix is the natural format for building a sparse matrix
+// incrementally; CsrCMatrix and CscCMatrix are the natural formats for
+// arithmetic.
+type CooCMatrix struct {
+	r, c int
+	rows []int
+	cols []int
+	vals []complex128
+}
+
+// NewCooCMatrix creates a new r×c CooCMatrix. If rows, cols and vals are
+// non-nil they are used as the initial triplets and must have equal
+// length; otherwise the matrix starts with no stored entries.
+//
+// NewCooCMatrix panics if any row or column index in rows or cols falls
+// outside [0, r) or [0, c) respectively.
+func NewCooCMatrix(r, c int, rows, cols []int, vals []complex128) *CooCMatrix {
+	if r <= 0 || c <= 0 {
+		panic(ErrZeroLength)
+	}
+	if rows == nil && cols == nil && vals == nil {
+		return &CooCMatrix{r: r, c: c}
+	}
+	if len(rows) != len(cols) || len(rows) != len(vals) {
+		panic(ErrShape)
+	}
+	for k, ri := range rows {
+		if ri < 0 || ri >= r || cols[k] < 0 || cols[k] >= c {
+			panic(ErrRowAccess)
+		}
+	}
+	return &CooCMatrix{r: r, c: c, rows: rows, cols: cols, vals: vals}
+}
+
+// Dims returns the dimensions of the matrix.
+func (a *CooCMatrix) Dims() (r, c int) { return a.r, a.c }
+
+// At returns the sum of all stored triplets at (i, j). At panics if i or j
+// are out of range.
+func (a *CooCMatrix) At(i, j int) complex128 {
+	if i < 0 || i >= a.r || j < 0 || j >= a.c {
+		panic(ErrRowAccess)
+	}
+	var v complex128
+	for k, ri := range a.rows {
+		if ri == i && a.cols[k] == j {
+			v += a.vals[k]
+		}
+	}
+	return v
+}
+
+// T returns the transpose of the matrix.
+func (a *CooCMatrix) T() CMatrix { return cSparseTranspose{a} }
+
+// H returns the conjugate transpose of the matrix.
+func (a *CooCMatrix) H() CMatrix { return ConjTranspose{a} }
+
+func (a *CooCMatrix) rowIter(f func(i, j int, v complex128)) {
+	for k, v := range a.vals {
+		f(a.rows[k], a.cols[k], v)
+	}
+}
+
+// AddEntry appends the triplet (i, j, v) to the matrix. It does not merge
+// with any existing entry already stored at (i, j); duplicates are summed
+// on conversion to a compressed format.
+func (a *CooCMatrix) AddEntry(i, j int, v complex128) {
+	if i < 0 || i >= a.r || j < 0 || j >= a.c {
+		panic(ErrRowAccess)
+	}
+	a.rows = append(a.rows, i)
+	a.cols = append(a.cols, j)
+	a.vals = append(a.vals, v)
+}
+
+// NNZ returns the number of stored triplets. This may be greater than the
+// number of structurally distinct non-zero entries if duplicates have
+// been added.
+func (a *CooCMatrix) NNZ() int { return len(a.vals) }
+
+// CsrCMatrix is a complex sparse matrix held in compressed sparse row
+// (CSR) format. The non-zero entries of row i are
+//
+//	ColIndices[RowOffsets[i]:RowOffsets[i+1]]
+//
+// with values taken from the same index range of Values. Within each row,
+// entries are sorted by column index and duplicates have been summed.
+type CsrCMatrix struct {
+	r, c int
+
+	RowOffsets []int
+	ColIndices []int
+	Values     []complex128
+}
+
+// Dims returns the dimensions of the matrix.
+func (a *CsrCMatrix) Dims() (r, c int) { return a.r, a.c }
+
+// At returns the element at (i, j). At panics if i or j are out of range.
+func (a *CsrCMatrix) At(i, j int) complex128 {
+	if i < 0 || i >= a.r || j < 0 || j >= a.c {
+		panic(ErrRowAccess)
+	}
+	row := a.ColIndices[a.RowOffsets[i]:a.RowOffsets[i+1]]
+	lo := sort.SearchInts(row, j)
+	if lo < len(row) && row[lo] == j {
+		return a.Values[a.RowOffsets[i]+lo]
+	}
+	return 0
+}
+
+// T returns the transpose of the matrix.
+func (a *CsrCMatrix) T() CMatrix { return cSparseTranspose{a} }
+
+// H returns the conjugate transpose of the matrix.
+func (a *CsrCMatrix) H() CMatrix { return ConjTranspose{a} }
+
+func (a *CsrCMatrix) rowIter(f func(i, j int, v complex128)) {
+	for i := 0; i < a.r; i++ {
+		for k := a.RowOffsets[i]; k < a.RowOffsets[i+1]; k++ {
+			f(i, a.ColIndices[k], a.Values[k])
+		}
+	}
+}
+
+// NNZ returns the number of explicitly stored entries.
+func (a *CsrCMatrix) NNZ() int { return len(a.Values) }
+
+// CscCMatrix is a complex sparse matrix held in compressed sparse column
+// (CSC) format, the column-major analogue of CsrCMatrix. The non-zero
+// entries of column j are
+//
+//	RowIndices[ColOffsets[j]:ColOffsets[j+1]]
+//
+// with values taken from the same index range of Values. Within each
+// column, entries are sorted by row index and duplicates have been
+// summed.
+type CscCMatrix struct {
+	r, c int
+
+	ColOffsets []int
+	RowIndices []int
+	Values     []complex128
+}
+
+// Dims returns the dimensions of the matrix.
+func (a *CscCMatrix) Dims() (r, c int) { return a.r, a.c }
+
+// At returns the element at (i, j). At panics if i or j are out of range.
+func (a *CscCMatrix) At(i, j int) complex128 {
+	if i < 0 || i >= a.r || j < 0 || j >= a.c {
+		panic(ErrRowAccess)
+	}
+	col := a.RowIndices[a.ColOffsets[j]:a.ColOffsets[j+1]]
+	lo := sort.SearchInts(col, i)
+	if lo < len(col) && col[lo] == i {
+		return a.Values[a.ColOffsets[j]+lo]
+	}
+	return 0
+}
+
+// T returns the transpose of the matrix.
+func (a *CscCMatrix) T() CMatrix { return cSparseTranspose{a} }
+
+// H returns the conjugate transpose of the matrix.
+func (a *CscCMatrix) H() CMatrix { return ConjTranspose{a} }
+
+func (a *CscCMatrix) rowIter(f func(i, j int, v complex128)) {
+	for j := 0; j < a.c; j++ {
+		for k := a.ColOffsets[j]; k < a.ColOffsets[j+1]; k++ {
+			f(a.RowIndices[k], j, a.Values[k])
+		}
+	}
+}
+
+// NNZ returns the number of explicitly stored entries.
+func (a *CscCMatrix) NNZ() int { return len(a.Values) }
+
+// cSparseTranspose is a transposed view of a sparse complex matrix. It
+// implements CMatrix but not sparseCMatrix, so operations that alias a
+// transposed sparse operand fall back to the generic At-based path.
+type cSparseTranspose struct {
+	m CMatrix
+}
+
+func (t cSparseTranspose) Dims() (r, c int) {
+	c, r = t.m.Dims()
+	return r, c
+}
+
+func (t cSparseTranspose) At(i, j int) complex128 { return t.m.At(j, i) }
+
+func (t cSparseTranspose) T() CMatrix { return t.m }
+
+func (t cSparseTranspose) H() CMatrix { return ConjTranspose{t} }
+
+// colValSorter sorts cols and the parallel slice vals by column index,
+// keeping the two in step.
+type colValSorter struct {
+	cols []int
+	vals []complex128
+}
+
+func (s colValSorter) Len() int { return len(s.cols) }
+func (s colValSorter) Less(i, j int) bool { return s.cols[i] < s.cols[j] }
+func (s colValSorter) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.vals[i], s.vals[j] = s.vals[j], s.vals[i]
+}
+
+// NewCsrFromCoo builds a CsrCMatrix from the triplets in a, summing
+// duplicate entries and sorting each row by column index.
+func NewCsrFromCoo(a *CooCMatrix) *CsrCMatrix {
+	r, c := a.Dims()
+	offsets := make([]int, r+1)
+	for _, ri := range a.rows {
+		offsets[ri+1]++
+	}
+	for i := 0; i < r; i++ {
+		offsets[i+1] += offsets[i]
+	}
+
+	colIdx := make([]int, len(a.vals))
+	vals := make([]complex128, len(a.vals))
+	next := append([]int(nil), offsets[:r]...)
+	for k, ri := range a.rows {
+		pos := next[ri]
+		colIdx[pos] = a.cols[k]
+		vals[pos] = a.vals[k]
+		next[ri]++
+	}
+
+	csr := &CsrCMatrix{r: r, c: c, RowOffsets: offsets, ColIndices: colIdx, Values: vals}
+	csr.sortAndSumDuplicates()
+	return csr
+}
+
+// sortAndSumDuplicates sorts the column indices of each row and merges
+// duplicate columns by summing their values, compacting ColIndices and
+// Values (and updating RowOffsets) in place.
+func (a *CsrCMatrix) sortAndSumDuplicates() {
+	newCols := a.ColIndices[:0]
+	newVals := a.Values[:0]
+	newOffsets := make([]int, len(a.RowOffsets))
+	for i := 0; i < a.r; i++ {
+		start, end := a.RowOffsets[i], a.RowOffsets[i+1]
+		row := a.ColIndices[start:end]
+		vals := a.Values[start:end]
+		sort.Sort(colValSorter{row, vals})
+
+		newOffsets[i] = len(newCols)
+		for k := 0; k < len(row); {
+			j := k + 1
+			sum := vals[k]
+			for j < len(row) && row[j] == row[k] {
+				sum += vals[j]
+				j++
+			}
+			newCols = append(newCols, row[k])
+			newVals = append(newVals, sum)
+			k = j
+		}
+	}
+	newOffsets[a.r] = len(newCols)
+	a.ColIndices = newCols
+	a.Values = newVals
+	a.RowOffsets = newOffsets
+}
+
+// NewCscFromCsr builds a CscCMatrix holding the same entries as a.
+func NewCscFromCsr(a *CsrCMatrix) *CscCMatrix {
+	r, c := a.Dims()
+	offsets := make([]int, c+1)
+	for _, cj := range a.ColIndices {
+		offsets[cj+1]++
+	}
+	for j := 0; j < c; j++ {
+		offsets[j+1] += offsets[j]
+	}
+
+	rowIdx := make([]int, len(a.Values))
+	vals := make([]complex128, len(a.Values))
+	next := append([]int(nil), offsets[:c]...)
+	for i := 0; i < r; i++ {
+		for k := a.RowOffsets[i]; k < a.RowOffsets[i+1]; k++ {
+			j := a.ColIndices[k]
+			pos := next[j]
+			rowIdx[pos] = i
+			vals[pos] = a.Values[k]
+			next[j]++
+		}
+	}
+	return &CscCMatrix{r: r, c: c, ColOffsets: offsets, RowIndices: rowIdx, Values: vals}
+}
+
+// NewCDenseFromCsr returns a dense copy of a.
+func NewCDenseFromCsr(a *CsrCMatrix) *CDense {
+	r, c := a.Dims()
+	m := NewCDense(r, c, nil)
+	a.rowIter(func(i, j int, v complex128) {
+		m.set(i, j, v)
+	})
+	return m
+}
+
+// NewCsrFromCDense builds a CsrCMatrix from the dense matrix a, omitting
+// any entry whose magnitude does not exceed tol. Passing tol == 0 retains
+// every entry that is not exactly zero.
+func NewCsrFromCDense(a CMatrix, tol float64) *CsrCMatrix {
+	r, c := a.Dims()
+	offsets := make([]int, r+1)
+	var cols []int
+	var vals []complex128
+	for i := 0; i < r; i++ {
+		offsets[i] = len(cols)
+		for j := 0; j < c; j++ {
+			v := a.At(i, j)
+			if cmplx.Abs(v) > tol {
+				cols = append(cols, j)
+				vals = append(vals, v)
+			}
+		}
+	}
+	offsets[r] = len(cols)
+	return &CsrCMatrix{r: r, c: c, RowOffsets: offsets, ColIndices: cols, Values: vals}
+}
+
+// addDenseSparse adds the sparse matrix s to dense, storing the result in
+// the receiver in O(nnz(s)) beyond the initial O(r*c) copy of dense.
+func (m *CDense) addDenseSparse(dense CMatrix, s sparseCMatrix) {
+	r, c := dense.Dims()
+	if m != dense {
+		m.reuseAsNonZeroed(r, c)
+		m.Copy(dense)
+	}
+	s.rowIter(func(i, j int, v complex128) {
+		m.set(i, j, m.at(i, j)+v)
+	})
+}
+
+// subDenseSparse computes dense - s, storing the result in the receiver.
+func (m *CDense) subDenseSparse(dense CMatrix, s sparseCMatrix) {
+	r, c := dense.Dims()
+	if m != dense {
+		m.reuseAsNonZeroed(r, c)
+		m.Copy(dense)
+	}
+	s.rowIter(func(i, j int, v complex128) {
+		m.set(i, j, m.at(i, j)-v)
+	})
+}
+
+// subSparseDense computes s - dense, storing the result in the receiver.
+func (m *CDense) subSparseDense(s sparseCMatrix, dense CMatrix) {
+	r, c := dense.Dims()
+	m.reuseAsNonZeroed(r, c)
+	m.Copy(dense)
+	m.Scale(-1, m)
+	s.rowIter(func(i, j int, v complex128) {
+		m.set(i, j, m.at(i, j)+v)
+	})
+}
+
+// mulCsrDense computes the matrix product a*b using a row-wise sparse
+// matrix-dense matrix multiply (SpMM), storing the result in the receiver.
+func (m *CDense) mulCsrDense(a *CsrCMatrix, b *CDense) {
+	_, bc := b.Dims()
+	m.reuseAsNonZeroed(a.r, bc)
+	m.Zero()
+	for i := 0; i < a.r; i++ {
+		for k := a.RowOffsets[i]; k < a.RowOffsets[i+1]; k++ {
+			aik := a.Values[k]
+			col := a.ColIndices[k]
+			for j := 0; j < bc; j++ {
+				m.set(i, j, m.at(i, j)+aik*b.at(col, j))
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat_test
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCooCMatrixRoundTrip(t *testing.T) {
+	t.Parallel()
+	coo := mat.NewCooCMatrix(2, 3, nil, nil, nil)
+	coo.AddEntry(0, 0, 1+1i)
+	coo.AddEntry(0, 2, 2i)
+	coo.AddEntry(1, 1, -3)
+
+	want := mat.NewCDense(2, 3, []complex128{
+		1 + 1i, 0, 2i,
+		0, -3, 0,
+	})
+
+	r, c := coo.Dims()
+	wr, wc := want.Dims()
+	if r != wr || c != wc {
+		t.Fatalf("Dims: got (%d, %d), want (%d, %d)", r, c, wr, wc)
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if coo.At(i, j) != want.At(i, j) {
+				t.Errorf("At(%d, %d): got %v, want %v", i, j, coo.At(i, j), want.At(i, j))
+			}
+		}
+	}
+	if coo.NNZ() != 3 {
+		t.Errorf("NNZ: got %d, want 3", coo.NNZ())
+	}
+}
+
+func TestCooCMatrixOutOfRangePanics(t *testing.T) {
+	t.Parallel()
+	if !panics(func() {
+		mat.NewCooCMatrix(2, 2, []int{0, 2}, []int{0, 0}, []complex128{1, 1})
+	}) {
+		t.Error("NewCooCMatrix with an out-of-range row index: expected a panic")
+	}
+	if !panics(func() {
+		mat.NewCooCMatrix(2, 2, []int{0, 0}, []int{0, -1}, []complex128{1, 1})
+	}) {
+		t.Error("NewCooCMatrix with an out-of-range column index: expected a panic")
+	}
+}
+
+func TestNewCsrFromCooSumsDuplicates(t *testing.T) {
+	t.Parallel()
+	coo := mat.NewCooCMatrix(2, 2, []int{0, 0, 1}, []int{1, 1, 0}, []complex128{1, 2, 3})
+	csr := mat.NewCsrFromCoo(coo)
+
+	if got, want := csr.At(0, 1), 3+0i; got != want {
+		t.Errorf("duplicate entries summed: got %v, want %v", got, want)
+	}
+	if got, want := csr.At(1, 0), 3+0i; got != want {
+		t.Errorf("At(1, 0): got %v, want %v", got, want)
+	}
+	if got, want := csr.NNZ(), 2; got != want {
+		t.Errorf("NNZ after summing duplicates: got %d, want %d", got, want)
+	}
+}
+
+func TestCsrCscRoundTrip(t *testing.T) {
+	t.Parallel()
+	dense := mat.NewCDense(3, 2, []complex128{
+		1, 0,
+		0, 2i,
+		3, 4,
+	})
+	csr := mat.NewCsrFromCDense(dense, 0)
+	csc := mat.NewCscFromCsr(csr)
+
+	r, c := csc.Dims()
+	if r != 3 || c != 2 {
+		t.Fatalf("Dims: got (%d, %d), want (3, 2)", r, c)
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if csc.At(i, j) != dense.At(i, j) {
+				t.Errorf("At(%d, %d): got %v, want %v", i, j, csc.At(i, j), dense.At(i, j))
+			}
+		}
+	}
+
+	back := mat.NewCDenseFromCsr(csr)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if back.At(i, j) != dense.At(i, j) {
+				t.Errorf("NewCDenseFromCsr At(%d, %d): got %v, want %v", i, j, back.At(i, j), dense.At(i, j))
+			}
+		}
+	}
+}
+
+func TestCsrMulDenseMatchesDense(t *testing.T) {
+	t.Parallel()
+	a := mat.NewCDense(2, 3, []complex128{
+		1, 0, 2i,
+		0, 3, 0,
+	})
+	b := mat.NewCDense(3, 2, []complex128{
+		1, 1i,
+		2, 0,
+		1, 1,
+	})
+	sparseA := mat.NewCsrFromCDense(a, 0)
+
+	var want, got mat.CDense
+	want.Mul(a, b)
+	got.Mul(sparseA, b)
+
+	r, c := want.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if diff := cmplx.Abs(got.At(i, j) - want.At(i, j)); diff > 1e-12 {
+				t.Errorf("sparse*dense at (%d, %d): got %v, want %v", i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
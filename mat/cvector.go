@@ -0,0 +1,177 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/cblas128"
+
+var (
+	vecCDense *VecCDense
+	_         CMatrix = vecCDense
+	_         CVector = vecCDense
+)
+
+// CVector is implemented by types that represent a column vector of
+// complex128 values.
+type CVector interface {
+	CMatrix
+
+	// AtVec returns the element at row i. AtVec panics if i is out of
+	// range.
+	AtVec(i int) complex128
+
+	// Len returns the number of elements in the vector.
+	Len() int
+}
+
+// VecCDense represents a column vector of complex128 values in dense
+// storage format.
+type VecCDense struct {
+	mat cblas128.Vector
+}
+
+// NewVecCDense creates a new VecCDense of length n. If data is non-nil it
+// is used as the backing slice and must have length n; otherwise a new
+// zeroed slice is allocated.
+//
+// NewVecCDense panics if n is zero.
+func NewVecCDense(n int, data []complex128) *VecCDense {
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if data == nil {
+		data = make([]complex128, n)
+	}
+	if len(data) != n {
+		panic(ErrShape)
+	}
+	return &VecCDense{mat: cblas128.Vector{N: n, Data: data, Inc: 1}}
+}
+
+// Len returns the length of the vector.
+func (v *VecCDense) Len() int { return v.mat.N }
+
+// Dims returns the dimensions of the vector, treated as an n×1 matrix.
+func (v *VecCDense) Dims() (r, c int) { return v.mat.N, 1 }
+
+// T returns the transpose of the vector, a 1×n row-vector view backed by
+// the same data.
+func (v *VecCDense) T() CMatrix { return cVectorTranspose{v} }
+
+// H returns the conjugate transpose of the vector.
+func (v *VecCDense) H() CMatrix { return ConjTranspose{v} }
+
+// At returns the element at (i, 0). At panics if i is out of range, or if
+// j is not zero.
+func (v *VecCDense) At(i, j int) complex128 {
+	if j != 0 {
+		panic(ErrRowAccess)
+	}
+	return v.AtVec(i)
+}
+
+// AtVec returns the i-th element of the vector. AtVec panics if i is out
+// of range.
+func (v *VecCDense) AtVec(i int) complex128 {
+	if i < 0 || i >= v.mat.N {
+		panic(ErrRowAccess)
+	}
+	return v.at(i)
+}
+
+func (v *VecCDense) at(i int) complex128 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the i-th element of the vector to val. SetVec panics if i is
+// out of range.
+func (v *VecCDense) SetVec(i int, val complex128) {
+	if i < 0 || i >= v.mat.N {
+		panic(ErrRowAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *VecCDense) setVec(i int, val complex128) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
+// IsEmpty returns whether the receiver is empty. Empty vectors can be the
+// receiver for size-restricted operations. The receiver can be emptied
+// using Reset.
+func (v *VecCDense) IsEmpty() bool {
+	return v.mat.Inc == 0
+}
+
+// Reset empties the vector so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+//
+// Reset should not be used when the vector shares backing data. See the
+// Reseter interface for more information.
+func (v *VecCDense) Reset() {
+	v.mat.Inc = 0
+	v.mat.N = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// reuseAsNonZeroed resizes an empty vector to length n, or checks that a
+// non-empty vector already has length n.
+func (v *VecCDense) reuseAsNonZeroed(n int) {
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if v.IsEmpty() {
+		v.mat = cblas128.Vector{
+			Inc:  1,
+			Data: useC(v.mat.Data, n),
+		}
+		v.mat.N = n
+		return
+	}
+	if n != v.mat.N {
+		panic(ErrShape)
+	}
+}
+
+// MulVec computes the matrix-vector product a*b, storing the result into
+// the receiver.
+//
+// MulVec panics if the number of columns of a does not equal the length of
+// b.
+func (v *VecCDense) MulVec(a CMatrix, b CVector) {
+	ar, ac := a.Dims()
+	if ac != b.Len() {
+		panic(ErrShape)
+	}
+
+	// Compute into a temporary so that MulVec behaves correctly when the
+	// receiver aliases a or b.
+	data := make([]complex128, ar)
+	for i := 0; i < ar; i++ {
+		var sum complex128
+		for k := 0; k < ac; k++ {
+			sum += a.At(i, k) * b.AtVec(k)
+		}
+		data[i] = sum
+	}
+
+	v.reuseAsNonZeroed(ar)
+	for i, x := range data {
+		v.setVec(i, x)
+	}
+}
+
+// cVectorTranspose is a transposed view of a CVector, presented as a 1×n
+// row matrix.
+type cVectorTranspose struct {
+	v CVector
+}
+
+func (t cVectorTranspose) Dims() (r, c int) { return 1, t.v.Len() }
+
+func (t cVectorTranspose) At(i, j int) complex128 { return t.v.AtVec(j) }
+
+func (t cVectorTranspose) T() CMatrix { return t.v }
+
+func (t cVectorTranspose) H() CMatrix { return ConjTranspose{t} }
@@ -0,0 +1,198 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mattest provides proptest-style generators and shrinkers for
+// writing property-based tests over mat's complex matrix types. A
+// generator builds random matrices with a controlled shape and value
+// distribution; a shrinker takes a matrix for which some property check
+// failed and searches for the smallest, simplest matrix for which the
+// check still fails, in the style of the Rust proptest crate.
+//
+// Generators are parameterized by a rand.Source so that a failing case
+// can be replayed deterministically from its seed.
+package mattest
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Seed returns a rand.Source seeded with seed, suitable for passing to the
+// generators in this package. Reusing the same seed reproduces the same
+// sequence of generated matrices.
+func Seed(seed int64) rand.Source {
+	return rand.NewSource(seed)
+}
+
+// Uniform returns a generator of independent complex values whose real
+// and imaginary parts are drawn uniformly from [-scale, scale].
+func Uniform(src rand.Source, scale float64) func() complex128 {
+	r := rand.New(src)
+	return func() complex128 {
+		return complex((r.Float64()*2-1)*scale, (r.Float64()*2-1)*scale)
+	}
+}
+
+// RandCDense returns a rows×cols matrix whose entries are drawn
+// independently from gen.
+func RandCDense(rows, cols int, gen func() complex128) *mat.CDense {
+	data := make([]complex128, rows*cols)
+	for i := range data {
+		data[i] = gen()
+	}
+	return mat.NewCDense(rows, cols, data)
+}
+
+// RandDiagCDense returns a random n×n diagonal matrix whose diagonal
+// entries are drawn independently from gen.
+func RandDiagCDense(n int, gen func() complex128) *mat.DiagCDense {
+	data := make([]complex128, n)
+	for i := range data {
+		data[i] = gen()
+	}
+	return mat.NewDiagCDense(n, data)
+}
+
+// RandHermitian returns a random n×n Hermitian matrix (A = Aᴴ). Entries
+// above the diagonal are drawn from gen; the corresponding entries below
+// the diagonal are their conjugates, and diagonal entries are drawn from
+// gen with their imaginary part discarded.
+func RandHermitian(n int, gen func() complex128) *mat.CDense {
+	m := mat.NewCDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			if i == j {
+				m.Set(i, i, complex(real(gen()), 0))
+				continue
+			}
+			v := gen()
+			m.Set(i, j, v)
+			m.Set(j, i, cmplx.Conj(v))
+		}
+	}
+	return m
+}
+
+// RandUnitary returns a random n×n unitary matrix (A·Aᴴ = I), built by
+// Gram-Schmidt orthonormalization of the columns of a matrix drawn from
+// gen.
+func RandUnitary(n int, gen func() complex128) *mat.CDense {
+	a := RandCDense(n, n, gen)
+	q := mat.NewCDense(n, n, nil)
+	for j := 0; j < n; j++ {
+		col := make([]complex128, n)
+		for i := 0; i < n; i++ {
+			col[i] = a.At(i, j)
+		}
+		for k := 0; k < j; k++ {
+			var proj complex128
+			for i := 0; i < n; i++ {
+				proj += cmplx.Conj(q.At(i, k)) * col[i]
+			}
+			for i := 0; i < n; i++ {
+				col[i] -= proj * q.At(i, k)
+			}
+		}
+		var norm float64
+		for _, v := range col {
+			norm += real(v)*real(v) + imag(v)*imag(v)
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			// Degenerate draw; fall back to the corresponding standard
+			// basis vector so the result stays unitary.
+			col[j] = 1
+			norm = 1
+		}
+		for i := 0; i < n; i++ {
+			q.Set(i, j, col[i]/complex(norm, 0))
+		}
+	}
+	return q
+}
+
+// RandLowRank returns a random m×n matrix of rank at most rank, formed as
+// the product of an m×rank and a rank×n matrix, each drawn from gen.
+func RandLowRank(m, n, rank int, gen func() complex128) *mat.CDense {
+	u := RandCDense(m, rank, gen)
+	v := RandCDense(rank, n, gen)
+	var out mat.CDense
+	out.Mul(u, v)
+	return &out
+}
+
+// Shrink searches for the smallest and simplest matrix derived from m for
+// which check still returns false, following proptest's shrinking
+// strategy: first shrink the shape by dropping trailing rows and columns,
+// then simplify individual entries by moving them towards zero. check
+// should return true when the property under test holds and false when it
+// is violated; Shrink looks for a smaller matrix that still violates it.
+func Shrink(m *mat.CDense, check func(*mat.CDense) bool) *mat.CDense {
+	cur := m
+	for {
+		next, shrunk := shrinkShape(cur, check)
+		if !shrunk {
+			break
+		}
+		cur = next
+	}
+	for {
+		next, shrunk := shrinkEntries(cur, check)
+		if !shrunk {
+			break
+		}
+		cur = next
+	}
+	return cur
+}
+
+// shrinkShape tries dropping the last row, then the last column, of m,
+// returning the first candidate for which check still fails.
+func shrinkShape(m *mat.CDense, check func(*mat.CDense) bool) (*mat.CDense, bool) {
+	r, c := m.Dims()
+	if r > 1 {
+		if cand := submatrix(m, r-1, c); !check(cand) {
+			return cand, true
+		}
+	}
+	if c > 1 {
+		if cand := submatrix(m, r, c-1); !check(cand) {
+			return cand, true
+		}
+	}
+	return m, false
+}
+
+func submatrix(m *mat.CDense, r, c int) *mat.CDense {
+	cand := mat.NewCDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			cand.Set(i, j, m.At(i, j))
+		}
+	}
+	return cand
+}
+
+// shrinkEntries tries halving each non-zero entry of m in turn, returning
+// the first candidate for which check still fails.
+func shrinkEntries(m *mat.CDense, check func(*mat.CDense) bool) (*mat.CDense, bool) {
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := m.At(i, j)
+			if v == 0 {
+				continue
+			}
+			cand := submatrix(m, r, c)
+			cand.Set(i, j, v/2)
+			if !check(cand) {
+				return cand, true
+			}
+		}
+	}
+	return m, false
+}